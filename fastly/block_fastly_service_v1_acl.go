@@ -22,57 +22,33 @@ func NewServiceACL(sa ServiceMetadata) ServiceAttributeDefinition {
 }
 
 func (h *ACLServiceAttributeHandler) Process(d *schema.ResourceData, latestVersion int, conn *gofastly.Client) error {
-	oldACLVal, newACLVal := d.GetChange(h.GetKey())
-	if oldACLVal == nil {
-		oldACLVal = new(schema.Set)
-	}
-	if newACLVal == nil {
-		newACLVal = new(schema.Set)
-	}
-
-	oldACLSet := oldACLVal.(*schema.Set)
-	newACLSet := newACLVal.(*schema.Set)
+	add, remove := h.diffSet(d, h.GetKey())
 
-	remove := oldACLSet.Difference(newACLSet).List()
-	add := newACLSet.Difference(oldACLSet).List()
-
-	// Delete removed ACL configurations
-	for _, vRaw := range remove {
-		val := vRaw.(map[string]interface{})
-		opts := gofastly.DeleteACLInput{
+	return batchProcess(add, remove, func(val map[string]interface{}) error {
+		opts := gofastly.CreateACLInput{
 			ServiceID:      d.Id(),
 			ServiceVersion: latestVersion,
 			Name:           val["name"].(string),
 		}
 
-		log.Printf("[DEBUG] Fastly ACL removal opts: %#v", opts)
-		err := conn.DeleteACL(&opts)
-
-		if errRes, ok := err.(*gofastly.HTTPError); ok {
-			if errRes.StatusCode != 404 {
-				return err
-			}
-		} else if err != nil {
-			return err
-		}
-	}
-
-	// POST new ACL configurations
-	for _, vRaw := range add {
-		val := vRaw.(map[string]interface{})
-		opts := gofastly.CreateACLInput{
+		log.Printf("[DEBUG] Fastly ACL creation opts: %#v", opts)
+		_, err := conn.CreateACL(&opts)
+		return err
+	}, func(val map[string]interface{}) error {
+		opts := gofastly.DeleteACLInput{
 			ServiceID:      d.Id(),
 			ServiceVersion: latestVersion,
 			Name:           val["name"].(string),
 		}
 
-		log.Printf("[DEBUG] Fastly ACL creation opts: %#v", opts)
-		_, err := conn.CreateACL(&opts)
-		if err != nil {
-			return err
+		log.Printf("[DEBUG] Fastly ACL removal opts: %#v", opts)
+		err := conn.DeleteACL(&opts)
+
+		if errRes, ok := err.(*gofastly.HTTPError); ok && errRes.StatusCode == 404 {
+			return nil
 		}
-	}
-	return nil
+		return err
+	}, BatchOptions{Parallelism: 1})
 }
 
 func (h *ACLServiceAttributeHandler) Read(d *schema.ResourceData, s *gofastly.ServiceDetail, conn *gofastly.Client) error {
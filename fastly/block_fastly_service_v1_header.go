@@ -0,0 +1,240 @@
+package fastly
+
+import (
+	"fmt"
+	"log"
+
+	gofastly "github.com/fastly/go-fastly/v2/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+type HeaderServiceAttributeHandler struct {
+	*DefaultServiceAttributeHandler
+}
+
+func NewServiceHeader(sa ServiceMetadata) ServiceAttributeDefinition {
+	return &HeaderServiceAttributeHandler{
+		&DefaultServiceAttributeHandler{
+			key:             "header",
+			serviceMetadata: sa,
+		},
+	}
+}
+
+func (h *HeaderServiceAttributeHandler) Process(d *schema.ResourceData, latestVersion int, conn *gofastly.Client) error {
+	oh, nh := d.GetChange(h.GetKey())
+	if oh == nil {
+		oh = new(schema.Set)
+	}
+	if nh == nil {
+		nh = new(schema.Set)
+	}
+
+	ohs := oh.(*schema.Set)
+	nhs := nh.(*schema.Set)
+	removeHeaders := ohs.Difference(nhs).List()
+	addHeaders := nhs.Difference(ohs).List()
+
+	// DELETE old Header configurations
+	for _, hRaw := range removeHeaders {
+		hf := hRaw.(map[string]interface{})
+		opts := gofastly.DeleteHeaderInput{
+			ServiceID:      d.Id(),
+			ServiceVersion: latestVersion,
+			Name:           hf["name"].(string),
+		}
+
+		log.Printf("[DEBUG] Fastly Header removal opts: %#v", opts)
+		err := conn.DeleteHeader(&opts)
+		if errRes, ok := err.(*gofastly.HTTPError); ok {
+			if errRes.StatusCode != 404 {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+	}
+
+	// POST new/updated Headers
+	for _, hRaw := range addHeaders {
+		hf := hRaw.(map[string]interface{})
+
+		opts, err := buildHeader(hf)
+		if err != nil {
+			log.Printf("[DEBUG] Error building Header: %s", err)
+			return err
+		}
+		opts.ServiceID = d.Id()
+		opts.ServiceVersion = latestVersion
+
+		log.Printf("[DEBUG] Create Header Opts: %#v", opts)
+		_, err = conn.CreateHeader(opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *HeaderServiceAttributeHandler) Read(d *schema.ResourceData, s *gofastly.ServiceDetail, conn *gofastly.Client) error {
+	log.Printf("[DEBUG] Refreshing Headers for (%s)", d.Id())
+	headerList, err := conn.ListHeaders(&gofastly.ListHeadersInput{
+		ServiceID:      d.Id(),
+		ServiceVersion: s.ActiveVersion.Number,
+	})
+
+	if err != nil {
+		return fmt.Errorf("[ERR] Error looking up Headers for (%s), version (%d): %s", d.Id(), s.ActiveVersion.Number, err)
+	}
+
+	hl := flattenHeaders(headerList)
+
+	if err := d.Set(h.GetKey(), hl); err != nil {
+		log.Printf("[WARN] Error setting Headers for (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func (h *HeaderServiceAttributeHandler) Register(s *schema.Resource) error {
+	s.Schema[h.GetKey()] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				// Required fields
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Unique name to refer to this Header object",
+				},
+				"action": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "set",
+					ValidateFunc: validateHeaderAction(),
+					Description:  "One of `set`, `append`, `delete`, `regex`, or `regex_repeat`",
+				},
+				"type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateHeaderType(),
+					Description:  "One of `request`, `fetch`, `cache`, or `response`",
+				},
+				"destination": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Header this affects",
+				},
+				// Optional fields
+				"ignore_if_set": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Don't add the header if it is already present, (Only applies to 'set' action.). Default `false`",
+				},
+				"source": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Variable to be used as a source for the header content (Does not apply to 'delete' action.)",
+				},
+				"regex": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Regular expression to use (Only applies to 'regex' and 'regex_repeat' actions.)",
+				},
+				"substitution": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Value to substitute in place of regular expression. (Only applies to 'regex' and 'regex_repeat'.)",
+				},
+				"priority": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     100,
+					Description: "Lower priorities execute first. (Default: 100.)",
+				},
+				"request_condition": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Name of a request condition to apply.",
+				},
+				"cache_condition": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Name of a cache condition to apply.",
+				},
+				"response_condition": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Name of a response condition to apply.",
+				},
+			},
+		},
+	}
+	return nil
+}
+
+func buildHeader(headerMap interface{}) (*gofastly.CreateHeaderInput, error) {
+	df := headerMap.(map[string]interface{})
+	opts := gofastly.CreateHeaderInput{
+		Name:              df["name"].(string),
+		IgnoreIfSet:       gofastly.Compatibool(df["ignore_if_set"].(bool)),
+		Destination:       df["destination"].(string),
+		Priority:          uint(df["priority"].(int)),
+		Source:            df["source"].(string),
+		Regex:             df["regex"].(string),
+		Substitution:      df["substitution"].(string),
+		RequestCondition:  df["request_condition"].(string),
+		CacheCondition:    df["cache_condition"].(string),
+		ResponseCondition: df["response_condition"].(string),
+	}
+
+	act := gofastly.HeaderAction(df["action"].(string))
+	opts.Action = act
+
+	typ := gofastly.HeaderType(df["type"].(string))
+	opts.Type = typ
+
+	return &opts, nil
+}
+
+func flattenHeaders(headerList []*gofastly.Header) []map[string]interface{} {
+	var hl []map[string]interface{}
+	for _, h := range headerList {
+		// Convert Header to a map for saving to state.
+		headerMapString := map[string]interface{}{
+			"name":               h.Name,
+			"action":             h.Action,
+			"ignore_if_set":      h.IgnoreIfSet,
+			"type":               h.Type,
+			"destination":        h.Destination,
+			"source":             h.Source,
+			"regex":              h.Regex,
+			"substitution":       h.Substitution,
+			"priority":           int(h.Priority),
+			"request_condition":  h.RequestCondition,
+			"cache_condition":    h.CacheCondition,
+			"response_condition": h.ResponseCondition,
+		}
+
+		// prune any empty values that come from the default string value in structs
+		for k, v := range headerMapString {
+			if v == "" {
+				delete(headerMapString, k)
+			}
+		}
+
+		hl = append(hl, headerMapString)
+	}
+	return hl
+}
+
+func validateHeaderAction() schema.SchemaValidateFunc {
+	return validateStringInSlice([]string{"set", "append", "delete", "regex", "regex_repeat"}, false)
+}
+
+func validateHeaderType() schema.SchemaValidateFunc {
+	return validateStringInSlice([]string{"request", "fetch", "cache", "response"}, false)
+}
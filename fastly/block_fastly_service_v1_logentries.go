@@ -22,43 +22,9 @@ func NewServiceLogentries(sa ServiceMetadata) ServiceAttributeDefinition {
 }
 
 func (h *LogentriesServiceAttributeHandler) Process(d *schema.ResourceData, latestVersion int, conn *gofastly.Client) error {
-	os, ns := d.GetChange(h.GetKey())
-	if os == nil {
-		os = new(schema.Set)
-	}
-	if ns == nil {
-		ns = new(schema.Set)
-	}
-
-	oss := os.(*schema.Set)
-	nss := ns.(*schema.Set)
-	removeLogentries := oss.Difference(nss).List()
-	addLogentries := nss.Difference(oss).List()
-
-	// DELETE old logentries configurations
-	for _, pRaw := range removeLogentries {
-		slf := pRaw.(map[string]interface{})
-		opts := gofastly.DeleteLogentriesInput{
-			ServiceID:      d.Id(),
-			ServiceVersion: latestVersion,
-			Name:           slf["name"].(string),
-		}
-
-		log.Printf("[DEBUG] Fastly Logentries removal opts: %#v", opts)
-		err := conn.DeleteLogentries(&opts)
-		if errRes, ok := err.(*gofastly.HTTPError); ok {
-			if errRes.StatusCode != 404 {
-				return err
-			}
-		} else if err != nil {
-			return err
-		}
-	}
-
-	// POST new/updated Logentries
-	for _, pRaw := range addLogentries {
-		slf := pRaw.(map[string]interface{})
+	add, remove := h.diffSet(d, h.GetKey())
 
+	return batchProcess(add, remove, func(slf map[string]interface{}) error {
 		var vla = h.getVCLLoggingAttributes(slf)
 		opts := gofastly.CreateLogentriesInput{
 			ServiceID:         d.Id(),
@@ -75,12 +41,21 @@ func (h *LogentriesServiceAttributeHandler) Process(d *schema.ResourceData, late
 
 		log.Printf("[DEBUG] Create Logentries Opts: %#v", opts)
 		_, err := conn.CreateLogentries(&opts)
-		if err != nil {
-			return err
+		return err
+	}, func(slf map[string]interface{}) error {
+		opts := gofastly.DeleteLogentriesInput{
+			ServiceID:      d.Id(),
+			ServiceVersion: latestVersion,
+			Name:           slf["name"].(string),
 		}
-	}
 
-	return nil
+		log.Printf("[DEBUG] Fastly Logentries removal opts: %#v", opts)
+		err := conn.DeleteLogentries(&opts)
+		if errRes, ok := err.(*gofastly.HTTPError); ok && errRes.StatusCode == 404 {
+			return nil
+		}
+		return err
+	}, BatchOptions{Parallelism: 1})
 }
 
 func (h *LogentriesServiceAttributeHandler) Read(d *schema.ResourceData, s *gofastly.ServiceDetail, conn *gofastly.Client) error {
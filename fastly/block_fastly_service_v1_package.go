@@ -1,8 +1,15 @@
 package fastly
 
 import (
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
+	"net/http"
+	"os"
 
 	gofastly "github.com/fastly/go-fastly/v2/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -22,6 +29,8 @@ func NewServicePackage(sa ServiceMetadata) ServiceAttributeDefinition {
 }
 
 func (h *PackageServiceAttributeHandler) Register(s *schema.Resource) error {
+	s.CustomizeDiff = chainCustomizeDiff(s.CustomizeDiff, validatePackageSource)
+
 	s.Schema[h.GetKey()] = &schema.Schema{
 		Type:     schema.TypeList,
 		Required: true,
@@ -30,14 +39,55 @@ func (h *PackageServiceAttributeHandler) Register(s *schema.Resource) error {
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"filename": {
-					Type:     schema.TypeString,
-					Required: true,
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{"package.0.content_base64", "package.0.source_url"},
+					Description:   "Path to a local Compute@Edge WASM package file",
+				},
+				"content_base64": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{"package.0.filename", "package.0.source_url"},
+					Description:   "The Compute@Edge WASM package content, base64 encoded",
+				},
+				"source_url": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{"package.0.filename", "package.0.content_base64"},
+					Description:   "HTTP(S) URL the Compute@Edge WASM package is fetched from at apply time",
 				},
 				// sha512 hash of the file
 				"source_code_hash": {
-					Type:     schema.TypeString,
-					Optional: true,
-					Computed: true,
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+					Description: "Used to trigger updates, this is the sha512 hash of the package file specified",
+				},
+				// Metadata surfaced from GetPackage, read-only.
+				"name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the Compute@Edge package",
+				},
+				"description": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Description of the Compute@Edge package",
+				},
+				"language": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Language of the Compute@Edge package",
+				},
+				"size": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Size of the Compute@Edge package, in bytes",
+				},
+				"files_hash": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Hash of the files within the Compute@Edge package",
 				},
 			},
 		},
@@ -50,16 +100,44 @@ func (h *PackageServiceAttributeHandler) Process(d *schema.ResourceData, latestV
 	if v, ok := d.GetOk(h.GetKey()); ok {
 		// Schema guarantees one package block.
 		Package := v.([]interface{})[0].(map[string]interface{})
-		packageFilename := Package["filename"].(string)
 
-		err := updatePackage(conn, &gofastly.UpdatePackageInput{
+		packagePath, cleanup, err := packageSourcePath(Package)
+		if err != nil {
+			return fmt.Errorf("Error resolving package source for %s: %s", d.Id(), err)
+		}
+		if cleanup != nil {
+			defer cleanup()
+		}
+
+		remotePackage, err := conn.GetPackage(&gofastly.GetPackageInput{
 			ServiceID:      d.Id(),
 			ServiceVersion: latestVersion,
-			PackagePath:    packageFilename,
 		})
 		if err != nil {
-			return fmt.Errorf("Error modifying package %s: %s", d.Id(), err)
+			if errRes, ok := err.(*gofastly.HTTPError); !ok || errRes.StatusCode != 404 {
+				return fmt.Errorf("Error looking up existing package for %s: %s", d.Id(), err)
+			}
+		}
+
+		localHash, err := hashPackageFile(packagePath)
+		if err != nil {
+			return fmt.Errorf("Error hashing package for %s: %s", d.Id(), err)
 		}
+
+		if remotePackage != nil && remotePackage.Metadata.HashSum == localHash {
+			return nil
+		}
+
+		return batchProcess([]map[string]interface{}{{"path": packagePath}}, nil, func(val map[string]interface{}) error {
+			if err := updatePackage(conn, &gofastly.UpdatePackageInput{
+				ServiceID:      d.Id(),
+				ServiceVersion: latestVersion,
+				PackagePath:    val["path"].(string),
+			}); err != nil {
+				return fmt.Errorf("Error modifying package %s: %s", d.Id(), err)
+			}
+			return nil
+		}, nil, BatchOptions{Parallelism: 1})
 	}
 
 	return nil
@@ -77,7 +155,9 @@ func (h *PackageServiceAttributeHandler) Read(d *schema.ResourceData, s *gofastl
 	}
 
 	filename := d.Get("package.0.filename").(string)
-	wp := flattenPackage(Package, filename)
+	contentBase64 := d.Get("package.0.content_base64").(string)
+	sourceURL := d.Get("package.0.source_url").(string)
+	wp := flattenPackage(Package, filename, contentBase64, sourceURL)
 	if err := d.Set(h.GetKey(), wp); err != nil {
 		log.Printf("[WARN] Error setting Package for (%s): %s", d.Id(), err)
 	}
@@ -85,16 +165,130 @@ func (h *PackageServiceAttributeHandler) Read(d *schema.ResourceData, s *gofastl
 	return nil
 }
 
+// validatePackageSource enforces exactly one of filename/content_base64/
+// source_url at plan time, so a bad package block fails before apply;
+// packageSourcePath still returns an error at apply time when none is set.
+func validatePackageSource(d *schema.ResourceDiff, meta interface{}) error {
+	v, ok := d.GetOk("package")
+	if !ok {
+		return nil
+	}
+
+	pkg := v.([]interface{})[0].(map[string]interface{})
+
+	var set int
+	for _, key := range []string{"filename", "content_base64", "source_url"} {
+		if s, ok := pkg[key].(string); ok && s != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("[ERR] Exactly one of package.filename, package.content_base64 or package.source_url must be set (found %d)", set)
+	}
+
+	return nil
+}
+
+// packageSourcePath resolves whichever of filename, content_base64 or
+// source_url is set on the package block into a local file path go-fastly
+// can upload. When the source has to be materialized (inline bytes or a
+// remote URL) the returned cleanup func removes the temp file; it is nil
+// when filename already points at a file on disk.
+func packageSourcePath(pkg map[string]interface{}) (string, func(), error) {
+	if v, ok := pkg["filename"].(string); ok && v != "" {
+		return v, nil, nil
+	}
+
+	tmp, err := ioutil.TempFile("", "fastly-package-*.wasm")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.Remove(tmp.Name()) }
+
+	if v, ok := pkg["content_base64"].(string); ok && v != "" {
+		data, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			cleanup()
+			return "", nil, fmt.Errorf("content_base64 is not valid base64: %s", err)
+		}
+		if _, err := tmp.Write(data); err != nil {
+			cleanup()
+			return "", nil, err
+		}
+		tmp.Close()
+		return tmp.Name(), cleanup, nil
+	}
+
+	if v, ok := pkg["source_url"].(string); ok && v != "" {
+		resp, err := http.Get(v)
+		if err != nil {
+			cleanup()
+			return "", nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			cleanup()
+			return "", nil, fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, v)
+		}
+
+		if _, err := io.Copy(tmp, resp.Body); err != nil {
+			cleanup()
+			return "", nil, err
+		}
+		tmp.Close()
+
+		if expected, ok := pkg["source_code_hash"].(string); ok && expected != "" {
+			actual, err := hashPackageFile(tmp.Name())
+			if err != nil {
+				cleanup()
+				return "", nil, err
+			}
+			if actual != expected {
+				cleanup()
+				return "", nil, fmt.Errorf("source_code_hash mismatch for %s: expected %s, got %s", v, expected, actual)
+			}
+		}
+
+		return tmp.Name(), cleanup, nil
+	}
+
+	cleanup()
+	return "", nil, fmt.Errorf("one of filename, content_base64 or source_url must be set")
+}
+
+func hashPackageFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha512.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func updatePackage(conn *gofastly.Client, i *gofastly.UpdatePackageInput) error {
 	_, err := conn.UpdatePackage(i)
 	return err
 }
 
-func flattenPackage(Package *gofastly.Package, filename string) []map[string]interface{} {
+func flattenPackage(Package *gofastly.Package, filename, contentBase64, sourceURL string) []map[string]interface{} {
 	var pa []map[string]interface{}
 	p := map[string]interface{}{
 		"source_code_hash": Package.Metadata.HashSum,
 		"filename":         filename,
+		"content_base64":   contentBase64,
+		"source_url":       sourceURL,
+		"name":             Package.Metadata.Name,
+		"description":      Package.Metadata.Description,
+		"language":         Package.Metadata.Language,
+		"size":             Package.Metadata.Size,
+		"files_hash":       Package.Metadata.FilesHash,
 	}
 
 	// Convert Package to a map for saving to state.
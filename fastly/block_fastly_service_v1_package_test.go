@@ -0,0 +1,128 @@
+package fastly
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestPackageSourcePathFilename(t *testing.T) {
+	f, err := ioutil.TempFile("", "fastly-package-test-*.wasm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.Close()
+
+	path, cleanup, err := packageSourcePath(map[string]interface{}{"filename": f.Name()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cleanup != nil {
+		t.Error("expected no cleanup func for filename source")
+	}
+	if path != f.Name() {
+		t.Errorf("path = %q, want %q", path, f.Name())
+	}
+}
+
+func TestPackageSourcePathContentBase64(t *testing.T) {
+	data := []byte("wasm bytes")
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	path, cleanup, err := packageSourcePath(map[string]interface{}{"content_base64": encoded})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cleanup == nil {
+		t.Fatal("expected a cleanup func for content_base64 source")
+	}
+	defer cleanup()
+
+	got, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(data) {
+		t.Errorf("file contents = %q, want %q", got, data)
+	}
+}
+
+func TestPackageSourcePathContentBase64Invalid(t *testing.T) {
+	_, _, err := packageSourcePath(map[string]interface{}{"content_base64": "not-valid-base64!"})
+	if err == nil {
+		t.Fatal("expected an error for invalid base64, got nil")
+	}
+}
+
+func TestPackageSourcePathSourceURL(t *testing.T) {
+	data := []byte("wasm bytes from url")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	}))
+	defer srv.Close()
+
+	expectedHash, err := hashPackageFile(writeTempFile(t, data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, cleanup, err := packageSourcePath(map[string]interface{}{
+		"source_url":       srv.URL,
+		"source_code_hash": expectedHash,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cleanup == nil {
+		t.Fatal("expected a cleanup func for source_url source")
+	}
+	defer cleanup()
+
+	got, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(data) {
+		t.Errorf("file contents = %q, want %q", got, data)
+	}
+}
+
+func TestPackageSourcePathSourceURLHashMismatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("actual content"))
+	}))
+	defer srv.Close()
+
+	_, _, err := packageSourcePath(map[string]interface{}{
+		"source_url":       srv.URL,
+		"source_code_hash": "deadbeef",
+	})
+	if err == nil {
+		t.Fatal("expected an error for source_code_hash mismatch, got nil")
+	}
+}
+
+func TestPackageSourcePathNoneSet(t *testing.T) {
+	_, _, err := packageSourcePath(map[string]interface{}{})
+	if err == nil {
+		t.Fatal("expected an error when no source is set, got nil")
+	}
+}
+
+func writeTempFile(t *testing.T, data []byte) string {
+	t.Helper()
+	f, err := ioutil.TempFile("", "fastly-package-test-*.wasm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Remove(f.Name()) })
+	return f.Name()
+}
@@ -0,0 +1,212 @@
+package fastly
+
+import (
+	"fmt"
+	"log"
+
+	gofastly "github.com/fastly/go-fastly/v2/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+type RequestSettingServiceAttributeHandler struct {
+	*DefaultServiceAttributeHandler
+}
+
+func NewServiceRequestSetting(sa ServiceMetadata) ServiceAttributeDefinition {
+	return &RequestSettingServiceAttributeHandler{
+		&DefaultServiceAttributeHandler{
+			key:             "request_setting",
+			serviceMetadata: sa,
+		},
+	}
+}
+
+func (h *RequestSettingServiceAttributeHandler) Process(d *schema.ResourceData, latestVersion int, conn *gofastly.Client) error {
+	or, nr := d.GetChange(h.GetKey())
+	if or == nil {
+		or = new(schema.Set)
+	}
+	if nr == nil {
+		nr = new(schema.Set)
+	}
+
+	ors := or.(*schema.Set)
+	nrs := nr.(*schema.Set)
+	removeRequestSettings := ors.Difference(nrs).List()
+	addRequestSettings := nrs.Difference(ors).List()
+
+	// DELETE old Request Settings configurations
+	for _, rRaw := range removeRequestSettings {
+		rf := rRaw.(map[string]interface{})
+		opts := gofastly.DeleteRequestSettingInput{
+			ServiceID:      d.Id(),
+			ServiceVersion: latestVersion,
+			Name:           rf["name"].(string),
+		}
+
+		log.Printf("[DEBUG] Fastly Request Setting removal opts: %#v", opts)
+		err := conn.DeleteRequestSetting(&opts)
+		if errRes, ok := err.(*gofastly.HTTPError); ok {
+			if errRes.StatusCode != 404 {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+	}
+
+	// POST new/updated Request Settings
+	for _, rRaw := range addRequestSettings {
+		rf := rRaw.(map[string]interface{})
+		opts := gofastly.CreateRequestSettingInput{
+			ServiceID:        d.Id(),
+			ServiceVersion:   latestVersion,
+			Name:             rf["name"].(string),
+			ForceMiss:        gofastly.Compatibool(rf["force_miss"].(bool)),
+			ForceSSL:         gofastly.Compatibool(rf["force_ssl"].(bool)),
+			Action:           gofastly.RequestSettingAction(rf["action"].(string)),
+			BypassBusyWait:   gofastly.Compatibool(rf["bypass_busy_wait"].(bool)),
+			MaxStaleAge:      uint(rf["max_stale_age"].(int)),
+			HashKeys:         rf["hash_keys"].(string),
+			XForwardedFor:    gofastly.RequestSettingXFF(rf["xff"].(string)),
+			TimerSupport:     gofastly.Compatibool(rf["timer_support"].(bool)),
+			GeoHeaders:       gofastly.Compatibool(rf["geo_headers"].(bool)),
+			DefaultHost:      rf["default_host"].(string),
+			RequestCondition: rf["request_condition"].(string),
+		}
+
+		log.Printf("[DEBUG] Create Request Setting Opts: %#v", opts)
+		_, err := conn.CreateRequestSetting(&opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *RequestSettingServiceAttributeHandler) Read(d *schema.ResourceData, s *gofastly.ServiceDetail, conn *gofastly.Client) error {
+	log.Printf("[DEBUG] Refreshing Request Settings for (%s)", d.Id())
+	rsList, err := conn.ListRequestSettings(&gofastly.ListRequestSettingsInput{
+		ServiceID:      d.Id(),
+		ServiceVersion: s.ActiveVersion.Number,
+	})
+
+	if err != nil {
+		return fmt.Errorf("[ERR] Error looking up Request Settings for (%s), version (%d): %s", d.Id(), s.ActiveVersion.Number, err)
+	}
+
+	rl := flattenRequestSettings(rsList)
+
+	if err := d.Set(h.GetKey(), rl); err != nil {
+		log.Printf("[WARN] Error setting Request Settings for (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func (h *RequestSettingServiceAttributeHandler) Register(s *schema.Resource) error {
+	s.Schema[h.GetKey()] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				// Required fields
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Unique name to refer to this Request Setting",
+				},
+				// Optional fields
+				"request_condition": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Name of a request condition to apply.",
+				},
+				"max_stale_age": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Description: "How old an object is allowed to be to serve stale-while-revalidate or stale-if-error, in seconds",
+				},
+				"force_miss": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Force a cache miss for the request",
+				},
+				"force_ssl": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Forces the request use SSL",
+				},
+				"action": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Allows you to terminate request handling and immediately perform an action",
+				},
+				"bypass_busy_wait": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Disable collapsed forwarding",
+				},
+				"hash_keys": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Comma separated list of varnish request object fields that should be in the hash key",
+				},
+				"xff": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "append",
+					Description: "X-Forwarded-For options",
+				},
+				"timer_support": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Injects the X-Timer info into the request",
+				},
+				"geo_headers": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Injects Fastly-Geo-Country, Fastly-Geo-City, and Fastly-Geo-Region into the request headers",
+				},
+				"default_host": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "the host header",
+				},
+			},
+		},
+	}
+	return nil
+}
+
+func flattenRequestSettings(rsList []*gofastly.RequestSetting) []map[string]interface{} {
+	var rl []map[string]interface{}
+	for _, r := range rsList {
+		rsMapString := map[string]interface{}{
+			"name":              r.Name,
+			"request_condition": r.RequestCondition,
+			"max_stale_age":     int(r.MaxStaleAge),
+			"force_miss":        r.ForceMiss,
+			"force_ssl":         r.ForceSSL,
+			"action":            r.Action,
+			"bypass_busy_wait":  r.BypassBusyWait,
+			"hash_keys":         r.HashKeys,
+			"xff":               r.XForwardedFor,
+			"timer_support":     r.TimerSupport,
+			"geo_headers":       r.GeoHeaders,
+			"default_host":      r.DefaultHost,
+		}
+
+		// prune any empty values that come from the default string value in structs
+		for k, v := range rsMapString {
+			if v == "" {
+				delete(rsMapString, k)
+			}
+		}
+
+		rl = append(rl, rsMapString)
+	}
+
+	return rl
+}
@@ -0,0 +1,178 @@
+package fastly
+
+import (
+	"fmt"
+	"log"
+
+	gofastly "github.com/fastly/go-fastly/v2/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+type ResponseObjectServiceAttributeHandler struct {
+	*DefaultServiceAttributeHandler
+}
+
+func NewServiceResponseObject(sa ServiceMetadata) ServiceAttributeDefinition {
+	return &ResponseObjectServiceAttributeHandler{
+		&DefaultServiceAttributeHandler{
+			key:             "response_object",
+			serviceMetadata: sa,
+		},
+	}
+}
+
+func (h *ResponseObjectServiceAttributeHandler) Process(d *schema.ResourceData, latestVersion int, conn *gofastly.Client) error {
+	or, nr := d.GetChange(h.GetKey())
+	if or == nil {
+		or = new(schema.Set)
+	}
+	if nr == nil {
+		nr = new(schema.Set)
+	}
+
+	ors := or.(*schema.Set)
+	nrs := nr.(*schema.Set)
+	removeResponseObject := ors.Difference(nrs).List()
+	addResponseObject := nrs.Difference(ors).List()
+
+	// DELETE old Response Object configurations
+	for _, rRaw := range removeResponseObject {
+		rf := rRaw.(map[string]interface{})
+		opts := gofastly.DeleteResponseObjectInput{
+			ServiceID:      d.Id(),
+			ServiceVersion: latestVersion,
+			Name:           rf["name"].(string),
+		}
+
+		log.Printf("[DEBUG] Fastly Response Object removal opts: %#v", opts)
+		err := conn.DeleteResponseObject(&opts)
+		if errRes, ok := err.(*gofastly.HTTPError); ok {
+			if errRes.StatusCode != 404 {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+	}
+
+	// POST new/updated Response Object
+	for _, rRaw := range addResponseObject {
+		rf := rRaw.(map[string]interface{})
+		opts := gofastly.CreateResponseObjectInput{
+			ServiceID:        d.Id(),
+			ServiceVersion:   latestVersion,
+			Name:             rf["name"].(string),
+			Status:           uint(rf["status"].(int)),
+			Response:         rf["response"].(string),
+			Content:          rf["content"].(string),
+			ContentType:      rf["content_type"].(string),
+			RequestCondition: rf["request_condition"].(string),
+			CacheCondition:   rf["cache_condition"].(string),
+		}
+
+		log.Printf("[DEBUG] Create Response Object Opts: %#v", opts)
+		_, err := conn.CreateResponseObject(&opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *ResponseObjectServiceAttributeHandler) Read(d *schema.ResourceData, s *gofastly.ServiceDetail, conn *gofastly.Client) error {
+	log.Printf("[DEBUG] Refreshing Response Objects for (%s)", d.Id())
+	roList, err := conn.ListResponseObjects(&gofastly.ListResponseObjectsInput{
+		ServiceID:      d.Id(),
+		ServiceVersion: s.ActiveVersion.Number,
+	})
+
+	if err != nil {
+		return fmt.Errorf("[ERR] Error looking up Response Object for (%s), version (%d): %s", d.Id(), s.ActiveVersion.Number, err)
+	}
+
+	rol := flattenResponseObjects(roList)
+
+	if err := d.Set(h.GetKey(), rol); err != nil {
+		log.Printf("[WARN] Error setting Response Object for (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func (h *ResponseObjectServiceAttributeHandler) Register(s *schema.Resource) error {
+	s.Schema[h.GetKey()] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				// Required fields
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Unique name to refer to this Response Object",
+				},
+				// Optional fields
+				"status": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     200,
+					Description: "The HTTP Status Code of the Response Object",
+				},
+				"response": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "Ok",
+					Description: "The HTTP Response of the Response Object",
+				},
+				"content": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The content to deliver for the Response Object",
+				},
+				"content_type": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The MIME type of the content",
+				},
+				"request_condition": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Name of the condition to be checked during the request phase to see if the Response Object should be delivered",
+				},
+				"cache_condition": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Name of the condition checked after we have retrieved an object, to see if we should deliver this Response Object instead",
+				},
+			},
+		},
+	}
+	return nil
+}
+
+func flattenResponseObjects(roList []*gofastly.ResponseObject) []map[string]interface{} {
+	var rol []map[string]interface{}
+	for _, ro := range roList {
+		roMapString := map[string]interface{}{
+			"name":              ro.Name,
+			"status":            ro.Status,
+			"response":          ro.Response,
+			"content":           ro.Content,
+			"content_type":      ro.ContentType,
+			"request_condition": ro.RequestCondition,
+			"cache_condition":   ro.CacheCondition,
+		}
+
+		// prune any empty values that come from the default string value in structs
+		for k, v := range roMapString {
+			if v == "" {
+				delete(roMapString, k)
+			}
+		}
+
+		rol = append(rol, roMapString)
+	}
+
+	return rol
+}
@@ -0,0 +1,212 @@
+package fastly
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"log"
+
+	gofastly "github.com/fastly/go-fastly/v2/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+type VCLServiceAttributeHandler struct {
+	*DefaultServiceAttributeHandler
+}
+
+func NewServiceVCL(sa ServiceMetadata) ServiceAttributeDefinition {
+	return &VCLServiceAttributeHandler{
+		&DefaultServiceAttributeHandler{
+			key:             "vcl",
+			serviceMetadata: sa,
+		},
+	}
+}
+
+func (h *VCLServiceAttributeHandler) Process(d *schema.ResourceData, latestVersion int, conn *gofastly.Client) error {
+	oldVCLVal, newVCLVal := d.GetChange(h.GetKey())
+	if oldVCLVal == nil {
+		oldVCLVal = new(schema.Set)
+	}
+	if newVCLVal == nil {
+		newVCLVal = new(schema.Set)
+	}
+
+	oldVCLSet := oldVCLVal.(*schema.Set)
+	newVCLSet := newVCLVal.(*schema.Set)
+
+	remove := oldVCLSet.Difference(newVCLSet).List()
+	add := newVCLSet.Difference(oldVCLSet).List()
+
+	// Delete removed VCL configurations
+	for _, vRaw := range remove {
+		val := vRaw.(map[string]interface{})
+		opts := gofastly.DeleteVCLInput{
+			ServiceID:      d.Id(),
+			ServiceVersion: latestVersion,
+			Name:           val["name"].(string),
+		}
+
+		log.Printf("[DEBUG] Fastly VCL removal opts: %#v", opts)
+		err := conn.DeleteVCL(&opts)
+
+		if errRes, ok := err.(*gofastly.HTTPError); ok {
+			if errRes.StatusCode != 404 {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+	}
+
+	// POST new VCL configurations
+	for _, vRaw := range add {
+		val := vRaw.(map[string]interface{})
+		opts := gofastly.CreateVCLInput{
+			ServiceID:      d.Id(),
+			ServiceVersion: latestVersion,
+			Name:           val["name"].(string),
+			Content:        val["content"].(string),
+		}
+
+		log.Printf("[DEBUG] Fastly VCL creation opts: %#v", opts)
+		_, err := conn.CreateVCL(&opts)
+		if err != nil {
+			return err
+		}
+
+		if val["main"].(bool) {
+			log.Printf("[DEBUG] Fastly VCL activation opts: service (%s), version (%d), name (%s)", d.Id(), latestVersion, val["name"].(string))
+			_, err := conn.ActivateVCL(&gofastly.ActivateVCLInput{
+				ServiceID:      d.Id(),
+				ServiceVersion: latestVersion,
+				Name:           val["name"].(string),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *VCLServiceAttributeHandler) Read(d *schema.ResourceData, s *gofastly.ServiceDetail, conn *gofastly.Client) error {
+
+	log.Printf("[DEBUG] Refreshing VCLs for (%s)", d.Id())
+	vclList, err := conn.ListVCLs(&gofastly.ListVCLsInput{
+		ServiceID:      d.Id(),
+		ServiceVersion: s.ActiveVersion.Number,
+	})
+	if err != nil {
+		return fmt.Errorf("[ERR] Error looking up VCLs for (%s), version (%v): %s", d.Id(), s.ActiveVersion.Number, err)
+	}
+
+	vl := flattenVCLs(vclList)
+
+	if err := d.Set(h.GetKey(), vl); err != nil {
+		log.Printf("[WARN] Error setting VCLs for (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func (h *VCLServiceAttributeHandler) Register(s *schema.Resource) error {
+	s.CustomizeDiff = chainCustomizeDiff(s.CustomizeDiff, validateVCLMain)
+
+	s.Schema[h.GetKey()] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				// Required fields
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Unique name to refer to this VCL",
+				},
+				"content": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The custom VCL code to upload",
+					StateFunc: func(v interface{}) string {
+						switch v.(type) {
+						case string:
+							hash := sha1.Sum([]byte(v.(string)))
+							return fmt.Sprintf("%x", hash)
+						default:
+							return ""
+						}
+					},
+				},
+				// Optional fields
+				"main": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether this VCL is the main VCL for the service, exactly one must be set",
+				},
+			},
+		},
+	}
+	return nil
+}
+
+// validateVCLMain enforces exactly one `main = true` VCL at plan time;
+// Process does not check the number of main VCLs itself.
+func validateVCLMain(d *schema.ResourceDiff, meta interface{}) error {
+	v, ok := d.GetOk("vcl")
+	if !ok {
+		return nil
+	}
+
+	var mainCount int
+	for _, vRaw := range v.(*schema.Set).List() {
+		val := vRaw.(map[string]interface{})
+		if val["main"].(bool) {
+			mainCount++
+		}
+	}
+	if mainCount != 1 {
+		return fmt.Errorf("[ERR] Exactly one custom VCL must be marked as main (found %d)", mainCount)
+	}
+
+	return nil
+}
+
+// chainCustomizeDiff composes CustomizeDiff funcs so a service attribute
+// handler can register its own validation without clobbering one set by
+// another handler.
+func chainCustomizeDiff(existing, next schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	if existing == nil {
+		return next
+	}
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if err := existing(d, meta); err != nil {
+			return err
+		}
+		return next(d, meta)
+	}
+}
+
+func flattenVCLs(vclList []*gofastly.VCL) []map[string]interface{} {
+	var vl []map[string]interface{}
+	for _, vcl := range vclList {
+		// Convert VCLs to a map for saving to state.
+		vclMap := map[string]interface{}{
+			"name":    vcl.Name,
+			"content": vcl.Content,
+			"main":    vcl.Main,
+		}
+
+		// prune any empty values that come from the default string value in structs
+		for k, v := range vclMap {
+			if v == "" {
+				delete(vclMap, k)
+			}
+		}
+
+		vl = append(vl, vclMap)
+	}
+
+	return vl
+}
@@ -0,0 +1,161 @@
+package fastly
+
+import (
+	"fmt"
+	"log"
+
+	gofastly "github.com/fastly/go-fastly/v2/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+type WAFServiceAttributeHandler struct {
+	*DefaultServiceAttributeHandler
+}
+
+func NewServiceWAF(sa ServiceMetadata) ServiceAttributeDefinition {
+	return &WAFServiceAttributeHandler{
+		&DefaultServiceAttributeHandler{
+			key:             "waf",
+			serviceMetadata: sa,
+		},
+	}
+}
+
+func (h *WAFServiceAttributeHandler) Process(d *schema.ResourceData, latestVersion int, conn *gofastly.Client) error {
+	oldWAFVal, newWAFVal := d.GetChange(h.GetKey())
+	if oldWAFVal == nil {
+		oldWAFVal = new(schema.Set)
+	}
+	if newWAFVal == nil {
+		newWAFVal = new(schema.Set)
+	}
+
+	oldWAFSet := oldWAFVal.(*schema.Set)
+	newWAFSet := newWAFVal.(*schema.Set)
+
+	remove := oldWAFSet.Difference(newWAFSet).List()
+	add := newWAFSet.Difference(oldWAFSet).List()
+
+	// Delete removed WAF
+	for _, vRaw := range remove {
+		val := vRaw.(map[string]interface{})
+		err := conn.DeleteWAF(&gofastly.DeleteWAFInput{
+			ID:      val["waf_id"].(string),
+			Version: latestVersion,
+		})
+
+		if errRes, ok := err.(*gofastly.HTTPError); ok {
+			if errRes.StatusCode != 404 {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+	}
+
+	// POST new WAF
+	for _, vRaw := range add {
+		val := vRaw.(map[string]interface{})
+		opts := gofastly.CreateWAFInput{
+			ServiceID:         d.Id(),
+			ServiceVersion:    latestVersion,
+			PrefetchCondition: val["prefetch_condition"].(string),
+			Response:          val["response_object"].(string),
+		}
+
+		log.Printf("[DEBUG] Fastly WAF creation opts: %#v", opts)
+		waf, err := conn.CreateWAF(&opts)
+		if err != nil {
+			return err
+		}
+
+		if _, err := conn.UpdateWAF(&gofastly.UpdateWAFInput{
+			ID:       waf.ID,
+			Version:  latestVersion,
+			Disabled: gofastly.Compatibool(val["disabled"].(bool)),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *WAFServiceAttributeHandler) Read(d *schema.ResourceData, s *gofastly.ServiceDetail, conn *gofastly.Client) error {
+
+	log.Printf("[DEBUG] Refreshing WAF for (%s)", d.Id())
+	wafList, err := conn.ListWAFs(&gofastly.ListWAFsInput{
+		ServiceID:      d.Id(),
+		ServiceVersion: s.ActiveVersion.Number,
+	})
+	if err != nil {
+		return fmt.Errorf("[ERR] Error looking up WAF for (%s), version (%v): %s", d.Id(), s.ActiveVersion.Number, err)
+	}
+
+	wl := flattenWAFs(wafList.Items)
+
+	if err := d.Set(h.GetKey(), wl); err != nil {
+		log.Printf("[WARN] Error setting WAF for (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func (h *WAFServiceAttributeHandler) Register(s *schema.Resource) error {
+	s.Schema[h.GetKey()] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				// Required fields
+				"prefetch_condition": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Name of the condition to determine if this request should be passed to the WAF for inspection",
+				},
+				"response_object": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Name of the response object used by the WAF to deliver custom errors",
+				},
+				// Optional fields
+				"waf_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the WAF",
+				},
+				"disabled": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether this WAF is disabled. In order to delete a service attached to a WAF, this attribute must be set to `true` first and applied",
+				},
+			},
+		},
+	}
+	return nil
+}
+
+func flattenWAFs(wafList []*gofastly.WAF) []map[string]interface{} {
+	var wl []map[string]interface{}
+	for _, waf := range wafList {
+		wafMap := map[string]interface{}{
+			"waf_id":             waf.ID,
+			"response_object":    waf.Response,
+			"prefetch_condition": waf.PrefetchCondition,
+			"disabled":           waf.Disabled,
+		}
+
+		// prune any empty values that come from the default string value in structs
+		for k, v := range wafMap {
+			if v == "" {
+				delete(wafMap, k)
+			}
+		}
+
+		wl = append(wl, wafMap)
+	}
+
+	return wl
+}
@@ -0,0 +1,109 @@
+package fastly
+
+import (
+	"sync"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// BatchOptions controls how batchProcess fans work out across a slice of
+// items: Parallelism bounds the worker pool, and MaxBatchSize lets callers
+// that talk to a Fastly batch endpoint (batch ACL entries, batch WAF active
+// rules, ...) chunk adds/removes to stay under that endpoint's own limit via
+// chunkMaps.
+type BatchOptions struct {
+	Parallelism  int
+	MaxBatchSize int
+}
+
+// diffSet computes the added and removed entries of a TypeSet attribute
+// between its old and new state, returning each element as the
+// map[string]interface{} that set elements are decoded to. This is the
+// GetChange/Difference boilerplate every handler's Process used to repeat.
+func (h *DefaultServiceAttributeHandler) diffSet(d *schema.ResourceData, key string) (add, remove []map[string]interface{}) {
+	oldVal, newVal := d.GetChange(key)
+	if oldVal == nil {
+		oldVal = new(schema.Set)
+	}
+	if newVal == nil {
+		newVal = new(schema.Set)
+	}
+
+	oldSet := oldVal.(*schema.Set)
+	newSet := newVal.(*schema.Set)
+
+	for _, v := range oldSet.Difference(newSet).List() {
+		remove = append(remove, v.(map[string]interface{}))
+	}
+	for _, v := range newSet.Difference(oldSet).List() {
+		add = append(add, v.(map[string]interface{}))
+	}
+
+	return add, remove
+}
+
+// batchProcess runs delete over removes and create over adds using a bounded
+// worker pool, aggregating every failure into a single multierror instead of
+// returning on the first one. Deletes run before creates, matching the
+// delete-then-create order every handler already used.
+func batchProcess(adds, removes []map[string]interface{}, create func(map[string]interface{}) error, delete func(map[string]interface{}) error, opts BatchOptions) error {
+	var errs *multierror.Error
+
+	for _, chunk := range chunkMaps(removes, opts.MaxBatchSize) {
+		errs = multierror.Append(errs, runWorkerPool(chunk, delete, opts.Parallelism))
+	}
+	for _, chunk := range chunkMaps(adds, opts.MaxBatchSize) {
+		errs = multierror.Append(errs, runWorkerPool(chunk, create, opts.Parallelism))
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func runWorkerPool(items []map[string]interface{}, fn func(map[string]interface{}) error, parallelism int) *multierror.Error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs *multierror.Error
+	sem := make(chan struct{}, parallelism)
+
+	for _, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(item map[string]interface{}) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := fn(item); err != nil {
+				mu.Lock()
+				errs = multierror.Append(errs, err)
+				mu.Unlock()
+			}
+		}(item)
+	}
+
+	wg.Wait()
+	return errs
+}
+
+// chunkMaps splits items into batches of at most size, for handlers backed
+// by a Fastly batch endpoint with its own size limit.
+func chunkMaps(items []map[string]interface{}, size int) [][]map[string]interface{} {
+	if size < 1 {
+		size = len(items)
+	}
+
+	var chunks [][]map[string]interface{}
+	for size < len(items) {
+		items, chunks = items[size:], append(chunks, items[0:size:size])
+	}
+	if len(items) > 0 {
+		chunks = append(chunks, items)
+	}
+
+	return chunks
+}
@@ -0,0 +1,83 @@
+package fastly
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestChunkMaps(t *testing.T) {
+	mk := func(n int) []map[string]interface{} {
+		items := make([]map[string]interface{}, n)
+		for i := range items {
+			items[i] = map[string]interface{}{"i": i}
+		}
+		return items
+	}
+
+	cases := []struct {
+		name       string
+		items      []map[string]interface{}
+		size       int
+		wantChunks []int
+	}{
+		{"empty", mk(0), 2, nil},
+		{"evenly divisible", mk(4), 2, []int{2, 2}},
+		{"remainder", mk(5), 2, []int{2, 2, 1}},
+		{"size larger than items", mk(3), 10, []int{3}},
+		{"size zero falls back to one chunk", mk(3), 0, []int{3}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := chunkMaps(c.items, c.size)
+			var gotSizes []int
+			for _, chunk := range got {
+				gotSizes = append(gotSizes, len(chunk))
+			}
+			if !reflect.DeepEqual(gotSizes, c.wantChunks) {
+				t.Fatalf("chunkMaps(%d items, size %d) chunk sizes = %v, want %v", len(c.items), c.size, gotSizes, c.wantChunks)
+			}
+
+			var total int
+			for _, chunk := range got {
+				total += len(chunk)
+			}
+			if total != len(c.items) {
+				t.Fatalf("chunkMaps dropped items: got %d total, want %d", total, len(c.items))
+			}
+		})
+	}
+}
+
+func TestRunWorkerPoolAggregatesErrors(t *testing.T) {
+	items := []map[string]interface{}{
+		{"i": 0}, {"i": 1}, {"i": 2}, {"i": 3},
+	}
+
+	errs := runWorkerPool(items, func(item map[string]interface{}) error {
+		if item["i"].(int)%2 == 0 {
+			return fmt.Errorf("failed on %d", item["i"])
+		}
+		return nil
+	}, 2)
+
+	if errs == nil {
+		t.Fatal("expected aggregated errors, got nil")
+	}
+	if got := len(errs.Errors); got != 2 {
+		t.Fatalf("expected 2 aggregated errors, got %d: %v", got, errs)
+	}
+}
+
+func TestRunWorkerPoolNoErrors(t *testing.T) {
+	items := []map[string]interface{}{{"i": 0}, {"i": 1}}
+
+	errs := runWorkerPool(items, func(map[string]interface{}) error {
+		return nil
+	}, 0)
+
+	if errs.ErrorOrNil() != nil {
+		t.Fatalf("expected no error, got %v", errs)
+	}
+}
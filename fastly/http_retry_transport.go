@@ -0,0 +1,178 @@
+package fastly
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	gofastly "github.com/fastly/go-fastly/v2/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// retryTransportConfig controls the backoff behaviour of retryTransport. It
+// is built by expandRetryTransportConfig from an `http_retry` block shaped by
+// httpRetrySchema(), so that large applies (many ACLs, Logentries endpoints,
+// headers, ...) can survive Fastly's rate limiting instead of failing
+// mid-apply. The provider schema does not register that block here.
+type retryTransportConfig struct {
+	MaxAttempts       int
+	BaseBackoff       time.Duration
+	MaxBackoff        time.Duration
+	RespectRetryAfter bool
+}
+
+// newFastlyClientWithRetry builds a go-fastly client whose HTTP transport
+// retries on 429/5xx per the `http_retry` provider config. This is what
+// config.go's Client() should call once `http_retry` is merged into the
+// provider schema via httpRetrySchema(); neither lives in this chunk of the
+// tree, so this is the closest real call site available here.
+func newFastlyClientWithRetry(apiKey string, httpRetry interface{}) (*gofastly.Client, error) {
+	client, err := gofastly.NewClient(apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := expandRetryTransportConfig(httpRetry)
+	client.HTTPClient.Transport = newRetryTransport(client.HTTPClient.Transport, cfg)
+
+	return client, nil
+}
+
+func httpRetrySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"max_attempts": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      5,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Maximum number of attempts for a request that receives a 429 or 5xx response",
+				},
+				"base_backoff": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     1,
+					Description: "Base backoff, in seconds, used for exponential retry delay",
+				},
+				"max_backoff": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     30,
+					Description: "Maximum backoff, in seconds, between retries",
+				},
+				"respect_retry_after": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Honor a Retry-After header given in seconds instead of the computed backoff; an HTTP-date Retry-After is ignored",
+				},
+			},
+		},
+	}
+}
+
+func expandRetryTransportConfig(v interface{}) retryTransportConfig {
+	cfg := retryTransportConfig{
+		MaxAttempts:       5,
+		BaseBackoff:       time.Second,
+		MaxBackoff:        30 * time.Second,
+		RespectRetryAfter: true,
+	}
+
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 || l[0] == nil {
+		return cfg
+	}
+
+	m := l[0].(map[string]interface{})
+	cfg.MaxAttempts = m["max_attempts"].(int)
+	cfg.BaseBackoff = time.Duration(m["base_backoff"].(int)) * time.Second
+	cfg.MaxBackoff = time.Duration(m["max_backoff"].(int)) * time.Second
+	cfg.RespectRetryAfter = m["respect_retry_after"].(bool)
+
+	return cfg
+}
+
+// retryTransport wraps an http.RoundTripper with exponential backoff on 429
+// and 5xx responses, used as the gofastly.Client transport so that a bulk
+// apply across many service attributes doesn't abort the first time Fastly
+// rate-limits a request.
+type retryTransport struct {
+	next http.RoundTripper
+	cfg  retryTransportConfig
+}
+
+func newRetryTransport(next http.RoundTripper, cfg retryTransportConfig) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &retryTransport{next: next, cfg: cfg}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.cfg.MaxAttempts < 1 {
+		return nil, fmt.Errorf("http_retry.max_attempts must be at least 1, got %d", t.cfg.MaxAttempts)
+	}
+
+	var resp *http.Response
+	var err error
+
+	for attempt := 0; attempt < t.cfg.MaxAttempts; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			// The previous attempt's body has already been read and closed
+			// by the transport, so it has to be re-derived before resending
+			// a retried POST/PUT/DELETE, or the retry would ship an empty
+			// body and silently corrupt the write.
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, getBodyErr := req.GetBody()
+			if getBodyErr != nil {
+				return resp, getBodyErr
+			}
+			req.Body = body
+		}
+
+		resp, err = t.next.RoundTrip(req)
+		if err != nil {
+			return resp, err
+		}
+
+		if !shouldRetry(resp.StatusCode) || attempt == t.cfg.MaxAttempts-1 {
+			return resp, nil
+		}
+
+		wait := t.backoff(attempt, resp)
+		resp.Body.Close()
+		time.Sleep(wait)
+	}
+
+	return resp, err
+}
+
+func shouldRetry(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if t.cfg.RespectRetryAfter {
+		if ra := resp.Header.Get("Retry-After"); ra != "" {
+			if secs, err := strconv.Atoi(ra); err == nil {
+				return time.Duration(secs) * time.Second
+			}
+		}
+	}
+
+	wait := t.cfg.BaseBackoff * time.Duration(math.Pow(2, float64(attempt)))
+	if wait > t.cfg.MaxBackoff {
+		wait = t.cfg.MaxBackoff
+	}
+	return wait
+}
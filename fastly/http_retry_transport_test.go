@@ -0,0 +1,126 @@
+package fastly
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryTransportBackoff(t *testing.T) {
+	rt := &retryTransport{cfg: retryTransportConfig{
+		BaseBackoff:       time.Second,
+		MaxBackoff:        10 * time.Second,
+		RespectRetryAfter: true,
+	}}
+
+	t.Run("exponential growth capped at MaxBackoff", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{}}
+		if got, want := rt.backoff(0, resp), time.Second; got != want {
+			t.Errorf("attempt 0: got %v, want %v", got, want)
+		}
+		if got, want := rt.backoff(1, resp), 2*time.Second; got != want {
+			t.Errorf("attempt 1: got %v, want %v", got, want)
+		}
+		if got, want := rt.backoff(2, resp), 4*time.Second; got != want {
+			t.Errorf("attempt 2: got %v, want %v", got, want)
+		}
+		if got, want := rt.backoff(10, resp), 10*time.Second; got != want {
+			t.Errorf("attempt 10 (should be capped): got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Retry-After takes precedence when respected", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+		if got, want := rt.backoff(0, resp), 3*time.Second; got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Retry-After ignored when RespectRetryAfter is false", func(t *testing.T) {
+		noRespect := &retryTransport{cfg: retryTransportConfig{
+			BaseBackoff:       time.Second,
+			MaxBackoff:        10 * time.Second,
+			RespectRetryAfter: false,
+		}}
+		resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+		if got, want := noRespect.backoff(0, resp), time.Second; got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestRetryTransportMaxAttemptsLessThanOne(t *testing.T) {
+	rt := newRetryTransport(http.DefaultTransport, retryTransportConfig{MaxAttempts: 0})
+	req, err := http.NewRequest("GET", "http://example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = rt.RoundTrip(req)
+	if err == nil {
+		t.Fatal("expected an error for MaxAttempts < 1, got nil")
+	}
+}
+
+func TestRetryTransportReplaysBodyOnRetry(t *testing.T) {
+	var bodies []string
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+
+		if len(bodies) < 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer upstream.Close()
+
+	rt := newRetryTransport(http.DefaultTransport, retryTransportConfig{
+		MaxAttempts: 3,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+	})
+
+	req, err := http.NewRequest("POST", upstream.URL, bytes.NewBufferString("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected eventual 200, got %d", resp.StatusCode)
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(bodies))
+	}
+	for i, b := range bodies {
+		if b != "payload" {
+			t.Errorf("attempt %d: body = %q, want %q (body not replayed on retry)", i, b, "payload")
+		}
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	cases := map[int]bool{
+		200: false,
+		404: false,
+		429: true,
+		500: true,
+		503: true,
+	}
+	for status, want := range cases {
+		if got := shouldRetry(status); got != want {
+			t.Errorf("shouldRetry(%d) = %v, want %v", status, got, want)
+		}
+	}
+}
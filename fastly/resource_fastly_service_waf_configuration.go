@@ -0,0 +1,370 @@
+package fastly
+
+import (
+	"fmt"
+	"log"
+
+	gofastly "github.com/fastly/go-fastly/v2/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// wafBatchExecutionUnit is the default number of per-rule operations sent to
+// Fastly's WAF batch endpoints in a single request. Fastly enforces a lower
+// ceiling on WAF batches than it does on the generic batch ACL/dictionary
+// endpoints, so this is kept configurable via the resource's `batch_size`.
+const wafBatchExecutionUnit = 500
+
+func resourceServiceWAFConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceServiceWAFConfigurationCreate,
+		Read:   resourceServiceWAFConfigurationRead,
+		Update: resourceServiceWAFConfigurationUpdate,
+		Delete: resourceServiceWAFConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"waf_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the WAF to configure",
+			},
+			"batch_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     wafBatchExecutionUnit,
+				Description: "Number of rule operations sent to the Fastly batch rule endpoint per request",
+			},
+
+			// OWASP tuning parameters.
+			"allowed_http_versions": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "HTTP/1.0 HTTP/1.1 HTTP/2",
+				Description: "Allowed HTTP versions",
+			},
+			"allowed_methods": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "GET HEAD POST OPTIONS PUT PATCH DELETE",
+				Description: "A space-separated list of HTTP method names",
+			},
+			"max_file_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     10000000,
+				Description: "Maximum allowed file size, in bytes",
+			},
+			"paranoia_level": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1,
+				Description: "The configured paranoia level, 1 through 4",
+			},
+			"inbound_anomaly_score_threshold": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     10,
+				Description: "Score threshold used to determine if the inbound anomaly score conditional takes effect",
+			},
+			"critical_anomaly_score": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     6,
+				Description: "Score value to add for critical anomalies",
+			},
+			"error_anomaly_score": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     5,
+				Description: "Score value to add for error anomalies",
+			},
+			"warning_anomaly_score": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     4,
+				Description: "Score value to add for warning anomalies",
+			},
+			"notice_anomaly_score": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     3,
+				Description: "Score value to add for notice anomalies",
+			},
+
+			// Active version surfaced after apply.
+			"active_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The currently active WAF configuration version",
+			},
+
+			"rule": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Per-rule status overrides applied to the cloned WAF version",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rule_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The modsecurity rule ID",
+						},
+						"status": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateWAFRuleStatus(),
+							Description:  "One of `log`, `block`, or `score`",
+						},
+					},
+				},
+			},
+
+			"rule_exclusion": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Rule exclusions applied to the cloned WAF version",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the exclusion",
+						},
+						"condition": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "A conditional expression evaluated to decide whether the exclusion applies",
+						},
+						"exclusion_type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Either `waf` (exclude the whole request) or `rule` (exclude only the listed rules)",
+						},
+						"rules": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "Rule IDs the exclusion applies to, only used when `exclusion_type` is `rule`",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceServiceWAFConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
+	d.SetId(d.Get("waf_id").(string))
+	return resourceServiceWAFConfigurationUpdate(d, meta)
+}
+
+func resourceServiceWAFConfigurationUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*FastlyClient).conn
+
+	waf, err := conn.GetWAF(&gofastly.GetWAFInput{ID: d.Id()})
+	if err != nil {
+		return fmt.Errorf("[ERR] Error looking up WAF (%s): %s", d.Id(), err)
+	}
+
+	cloned, err := conn.CloneWAFVersion(&gofastly.CloneWAFVersionInput{
+		WAFID:            d.Id(),
+		WAFVersionNumber: waf.WAFVersion.Number,
+	})
+	if err != nil {
+		return fmt.Errorf("[ERR] Error cloning WAF version for (%s): %s", d.Id(), err)
+	}
+
+	if _, err := conn.UpdateWAFVersion(&gofastly.UpdateWAFVersionInput{
+		WAFID:                        d.Id(),
+		WAFVersionNumber:             cloned.Number,
+		AllowedHTTPVersions:          d.Get("allowed_http_versions").(string),
+		AllowedMethods:               d.Get("allowed_methods").(string),
+		MaxFileSize:                  d.Get("max_file_size").(int),
+		ParanoiaLevel:                d.Get("paranoia_level").(int),
+		InboundAnomalyScoreThreshold: d.Get("inbound_anomaly_score_threshold").(int),
+		CriticalAnomalyScore:         d.Get("critical_anomaly_score").(int),
+		ErrorAnomalyScore:            d.Get("error_anomaly_score").(int),
+		WarningAnomalyScore:          d.Get("warning_anomaly_score").(int),
+		NoticeAnomalyScore:           d.Get("notice_anomaly_score").(int),
+	}); err != nil {
+		return fmt.Errorf("[ERR] Error updating WAF version (%s): %s", d.Id(), err)
+	}
+
+	batchSize := d.Get("batch_size").(int)
+
+	if v, ok := d.GetOk("rule"); ok {
+		rules := v.(*schema.Set).List()
+		if err := batchWAFRules(conn, d.Id(), cloned.Number, rules, batchSize); err != nil {
+			return err
+		}
+	}
+
+	if v, ok := d.GetOk("rule_exclusion"); ok {
+		exclusions := v.(*schema.Set).List()
+		if err := reconcileWAFRuleExclusions(conn, d.Id(), cloned.Number, exclusions); err != nil {
+			return err
+		}
+	}
+
+	if _, err := conn.UpdateWAFVersion(&gofastly.UpdateWAFVersionInput{
+		WAFID:            d.Id(),
+		WAFVersionNumber: cloned.Number,
+		Locked:           gofastly.Compatibool(true),
+	}); err != nil {
+		return fmt.Errorf("[ERR] Error locking WAF version (%s): %s", d.Id(), err)
+	}
+
+	if _, err := conn.ActivateWAFVersion(&gofastly.ActivateWAFVersionInput{
+		WAFID:            d.Id(),
+		WAFVersionNumber: cloned.Number,
+	}); err != nil {
+		return fmt.Errorf("[ERR] Error activating WAF version (%s): %s", d.Id(), err)
+	}
+
+	return resourceServiceWAFConfigurationRead(d, meta)
+}
+
+func batchWAFRules(conn *gofastly.Client, wafID string, version int, rules []interface{}, batchSize int) error {
+	for start := 0; start < len(rules); start += batchSize {
+		end := start + batchSize
+		if end > len(rules) {
+			end = len(rules)
+		}
+
+		var ops []*gofastly.BatchModificationWAFActiveRule
+		for _, rRaw := range rules[start:end] {
+			r := rRaw.(map[string]interface{})
+			ops = append(ops, &gofastly.BatchModificationWAFActiveRule{
+				ModSecID: r["rule_id"].(int),
+				Status:   r["status"].(string),
+				OP:       gofastly.UpsertBatchOperation,
+			})
+		}
+
+		log.Printf("[DEBUG] Batching %d WAF active rule updates for (%s)", len(ops), wafID)
+		if err := conn.BatchModificationWAFActiveRules(&gofastly.BatchModificationWAFActiveRulesInput{
+			WAFID:            wafID,
+			WAFVersionNumber: version,
+			WAFActiveRules:   ops,
+		}); err != nil {
+			return fmt.Errorf("[ERR] Error batch-updating WAF active rules for (%s): %s", wafID, err)
+		}
+	}
+
+	return nil
+}
+
+// reconcileWAFRuleExclusions diffs the exclusions in config against what the
+// cloned WAF version already carries forward from its parent version, only
+// deleting the ones no longer wanted and creating the ones that are new.
+// CloneWAFVersion copies exclusions along with everything else, so a blind
+// create-everything-every-time approach fails with a duplicate-name error on
+// the second apply. Unlike active rules, go-fastly v2 has no batch endpoint
+// for WAF rule exclusions, so each create/delete is its own API call.
+func reconcileWAFRuleExclusions(conn *gofastly.Client, wafID string, version int, desired []interface{}) error {
+	existing, err := conn.ListWAFRuleExclusions(&gofastly.ListWAFRuleExclusionsInput{
+		WAFID:            wafID,
+		WAFVersionNumber: version,
+	})
+	if err != nil {
+		return fmt.Errorf("[ERR] Error looking up existing WAF rule exclusions for (%s): %s", wafID, err)
+	}
+
+	existingByName := make(map[string]*gofastly.WAFRuleExclusion, len(existing.Items))
+	for _, e := range existing.Items {
+		existingByName[e.Name] = e
+	}
+
+	desiredByName := make(map[string]map[string]interface{}, len(desired))
+	for _, eRaw := range desired {
+		e := eRaw.(map[string]interface{})
+		desiredByName[e["name"].(string)] = e
+	}
+
+	for name, e := range existingByName {
+		if _, ok := desiredByName[name]; ok {
+			continue
+		}
+
+		if err := conn.DeleteWAFRuleExclusion(&gofastly.DeleteWAFRuleExclusionInput{
+			WAFID:            wafID,
+			WAFVersionNumber: version,
+			Number:           e.Number,
+		}); err != nil {
+			return fmt.Errorf("[ERR] Error deleting WAF rule exclusion (%s) for (%s): %s", name, wafID, err)
+		}
+	}
+
+	for name, e := range desiredByName {
+		if _, ok := existingByName[name]; ok {
+			continue
+		}
+
+		var ruleIDs []int
+		if rs, ok := e["rules"].(*schema.Set); ok {
+			for _, rRaw := range rs.List() {
+				ruleIDs = append(ruleIDs, rRaw.(int))
+			}
+		}
+
+		if _, err := conn.CreateWAFRuleExclusion(&gofastly.CreateWAFRuleExclusionInput{
+			WAFID:            wafID,
+			WAFVersionNumber: version,
+			WAFRuleExclusion: &gofastly.WAFRuleExclusion{
+				Name:          name,
+				Condition:     e["condition"].(string),
+				ExclusionType: e["exclusion_type"].(string),
+			},
+			Rules: ruleIDs,
+		}); err != nil {
+			return fmt.Errorf("[ERR] Error creating WAF rule exclusion (%s) for (%s): %s", name, wafID, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceServiceWAFConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*FastlyClient).conn
+
+	waf, err := conn.GetWAF(&gofastly.GetWAFInput{ID: d.Id()})
+	if err != nil {
+		return fmt.Errorf("[ERR] Error looking up WAF (%s): %s", d.Id(), err)
+	}
+
+	d.Set("waf_id", waf.ID)
+	d.Set("active_version", waf.WAFVersion.Number)
+
+	rules, err := conn.ListWAFActiveRules(&gofastly.ListWAFActiveRulesInput{
+		WAFID:            d.Id(),
+		WAFVersionNumber: waf.WAFVersion.Number,
+	})
+	if err != nil {
+		return fmt.Errorf("[ERR] Error looking up WAF active rules (%s): %s", d.Id(), err)
+	}
+
+	var rl []map[string]interface{}
+	for _, r := range rules.Items {
+		rl = append(rl, map[string]interface{}{
+			"rule_id": r.ModSecID,
+			"status":  r.Status,
+		})
+	}
+	if err := d.Set("rule", rl); err != nil {
+		log.Printf("[WARN] Error setting WAF rules for (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceServiceWAFConfigurationDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+func validateWAFRuleStatus() schema.SchemaValidateFunc {
+	return validateStringInSlice([]string{"log", "block", "score"}, false)
+}
@@ -0,0 +1,13 @@
+package fastly
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// validateStringInSlice is a thin wrapper around the SDK's validation helper
+// so call sites across the service attribute handlers don't each need their
+// own import of helper/validation.
+func validateStringInSlice(valid []string, ignoreCase bool) schema.SchemaValidateFunc {
+	return validation.StringInSlice(valid, ignoreCase)
+}